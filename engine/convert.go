@@ -5,11 +5,25 @@
 package engine
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-func toPod(spec *Spec) *v1.Pod {
+func toPod(spec *Spec) (*v1.Pod, error) {
+	initContainers, err := toServiceContainers(spec)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := toContainers(spec)
+	if err != nil {
+		return nil, err
+	}
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        spec.PodSpec.Name,
@@ -18,14 +32,224 @@ func toPod(spec *Spec) *v1.Pod {
 			Labels:      spec.PodSpec.Labels,
 		},
 		Spec: v1.PodSpec{
-			ServiceAccountName: spec.PodSpec.ServiceAccountName,
-			RestartPolicy:      v1.RestartPolicyNever,
-			Volumes:            toVolumes(spec),
-			Containers:         toContainers(spec),
-			NodeSelector:       spec.PodSpec.NodeSelector,
-			Tolerations:        toTolerations(spec),
+			ServiceAccountName:        spec.PodSpec.ServiceAccountName,
+			RestartPolicy:             v1.RestartPolicyNever,
+			Volumes:                   append(toVolumes(spec), toSecretVolumes(spec)...),
+			InitContainers:            initContainers,
+			Containers:                containers,
+			NodeSelector:              spec.PodSpec.NodeSelector,
+			Tolerations:               toTolerations(spec),
+			HostAliases:               toHostAliases(spec),
+			ImagePullSecrets:          toPullSecrets(spec),
+			SecurityContext:           toPodSecurityContext(spec),
+			Affinity:                  toAffinity(spec),
+			TopologySpreadConstraints: toTopologySpreadConstraints(spec),
 		},
+	}, nil
+}
+
+func toAffinity(spec *Spec) *v1.Affinity {
+	a := spec.PodSpec.Affinity
+	if a.NodeAffinity == nil && a.PodAffinity == nil && a.PodAntiAffinity == nil {
+		return nil
+	}
+
+	affinity := &v1.Affinity{}
+	if a.NodeAffinity != nil {
+		affinity.NodeAffinity = toNodeAffinity(a.NodeAffinity)
+	}
+	if a.PodAffinity != nil {
+		affinity.PodAffinity = &v1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  toPodAffinityTerms(a.PodAffinity.Required),
+			PreferredDuringSchedulingIgnoredDuringExecution: toWeightedPodAffinityTerms(a.PodAffinity.Preferred),
+		}
+	}
+	if a.PodAntiAffinity != nil {
+		affinity.PodAntiAffinity = &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  toPodAffinityTerms(a.PodAntiAffinity.Required),
+			PreferredDuringSchedulingIgnoredDuringExecution: toWeightedPodAffinityTerms(a.PodAntiAffinity.Preferred),
+		}
+	}
+	return affinity
+}
+
+func toNodeAffinity(n *NodeAffinity) *v1.NodeAffinity {
+	affinity := &v1.NodeAffinity{}
+	if len(n.Required) > 0 {
+		affinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{
+				{MatchExpressions: toNodeSelectorRequirements(n.Required)},
+			},
+		}
+	}
+	for _, p := range n.Preferred {
+		affinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			v1.PreferredSchedulingTerm{
+				Weight: p.Weight,
+				Preference: v1.NodeSelectorTerm{
+					MatchExpressions: toNodeSelectorRequirements([]MatchExpression{p.MatchExpression}),
+				},
+			},
+		)
+	}
+	return affinity
+}
+
+func toNodeSelectorRequirements(exprs []MatchExpression) []v1.NodeSelectorRequirement {
+	var reqs []v1.NodeSelectorRequirement
+	for _, e := range exprs {
+		reqs = append(reqs, v1.NodeSelectorRequirement{
+			Key:      e.Key,
+			Operator: v1.NodeSelectorOperator(e.Operator),
+			Values:   e.Values,
+		})
 	}
+	return reqs
+}
+
+func toPodAffinityTerms(terms []PodAffinityTerm) []v1.PodAffinityTerm {
+	var out []v1.PodAffinityTerm
+	for _, t := range terms {
+		out = append(out, v1.PodAffinityTerm{
+			LabelSelector: toLabelSelector(t.LabelSelector),
+			TopologyKey:   t.TopologyKey,
+		})
+	}
+	return out
+}
+
+func toWeightedPodAffinityTerms(terms []WeightedPodAffinityTerm) []v1.WeightedPodAffinityTerm {
+	var out []v1.WeightedPodAffinityTerm
+	for _, t := range terms {
+		out = append(out, v1.WeightedPodAffinityTerm{
+			Weight: t.Weight,
+			PodAffinityTerm: v1.PodAffinityTerm{
+				LabelSelector: toLabelSelector(t.LabelSelector),
+				TopologyKey:   t.TopologyKey,
+			},
+		})
+	}
+	return out
+}
+
+func toLabelSelector(exprs []MatchExpression) *metav1.LabelSelector {
+	if len(exprs) == 0 {
+		return nil
+	}
+	var reqs []metav1.LabelSelectorRequirement
+	for _, e := range exprs {
+		reqs = append(reqs, metav1.LabelSelectorRequirement{
+			Key:      e.Key,
+			Operator: metav1.LabelSelectorOperator(e.Operator),
+			Values:   e.Values,
+		})
+	}
+	return &metav1.LabelSelector{MatchExpressions: reqs}
+}
+
+func toTopologySpreadConstraints(spec *Spec) []v1.TopologySpreadConstraint {
+	var out []v1.TopologySpreadConstraint
+	for _, c := range spec.PodSpec.TopologySpreadConstraints {
+		out = append(out, v1.TopologySpreadConstraint{
+			MaxSkew:           c.MaxSkew,
+			TopologyKey:       c.TopologyKey,
+			WhenUnsatisfiable: v1.UnsatisfiableConstraintAction(c.WhenUnsatisfiable),
+			LabelSelector:     toLabelSelector(c.LabelSelector),
+		})
+	}
+	return out
+}
+
+func toPodSecurityContext(spec *Spec) *v1.PodSecurityContext {
+	sc := spec.PodSpec.SecurityContext
+	context := &v1.PodSecurityContext{
+		RunAsUser:          sc.RunAsUser,
+		FSGroup:            sc.FSGroup,
+		SupplementalGroups: sc.SupplementalGroups,
+	}
+	if sc.SeccompProfile != "" {
+		context.SeccompProfile = &v1.SeccompProfile{
+			Type:             v1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: stringptr(sc.SeccompProfile),
+		}
+	}
+	return context
+}
+
+// toPullSecrets references the pipeline's named pull secrets,
+// plus the auto-created registry secret (see toRegistrySecret)
+// when the pod spec carries resolved registry auths.
+func toPullSecrets(spec *Spec) []v1.LocalObjectReference {
+	var refs []v1.LocalObjectReference
+	for _, name := range spec.PodSpec.PullSecrets {
+		refs = append(refs, v1.LocalObjectReference{Name: name})
+	}
+	if len(spec.PodSpec.RegistryAuths) > 0 {
+		refs = append(refs, v1.LocalObjectReference{Name: registrySecretName(spec)})
+	}
+	return refs
+}
+
+// registrySecretName returns the name of the auto-created
+// registry secret, matching what toPullSecrets attaches to the
+// pod so the two stay in sync.
+func registrySecretName(spec *Spec) string {
+	return spec.PodSpec.Name + "-auths"
+}
+
+// toRegistrySecret builds the docker-config Secret that backs
+// the pod's auto-created ImagePullSecret, from the registry
+// plugin's resolved auths. It returns nil if the pod spec does
+// not carry any. The caller is responsible for creating this
+// Secret alongside the pod, in the same namespace, with an
+// ownerReference to the pod so it is garbage collected when the
+// pod is removed.
+func toRegistrySecret(spec *Spec) *v1.Secret {
+	if len(spec.PodSpec.RegistryAuths) == 0 {
+		return nil
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registrySecretName(spec),
+			Namespace: spec.PodSpec.Namespace,
+		},
+		Type: v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			v1.DockerConfigJsonKey: spec.PodSpec.RegistryAuths,
+		},
+	}
+}
+
+func toHostAliases(spec *Spec) []v1.HostAlias {
+	var aliases []v1.HostAlias
+	for _, alias := range spec.PodSpec.HostAliases {
+		aliases = append(aliases, v1.HostAlias{
+			IP:        alias.IP,
+			Hostnames: alias.Hostnames,
+		})
+	}
+	return aliases
+}
+
+// ParseExtraHost parses a pipeline-declared extra host in the
+// form "hostname:ip" and validates that ip is a well-formed
+// IPv4 or IPv6 address. The hostname is split off at the first
+// colon, since hostnames cannot contain one but IPv6 addresses
+// do.
+func ParseExtraHost(s string) (HostAlias, error) {
+	i := strings.Index(s, ":")
+	if i < 0 {
+		return HostAlias{}, fmt.Errorf("extra_hosts entry %q must be in the form hostname:ip", s)
+	}
+	host, ip := s[:i], s[i+1:]
+	if host == "" {
+		return HostAlias{}, fmt.Errorf("extra_hosts entry %q is missing a hostname", s)
+	}
+	if net.ParseIP(ip) == nil {
+		return HostAlias{}, fmt.Errorf("extra_hosts entry %q has an invalid ip address %q", s, ip)
+	}
+	return HostAlias{IP: ip, Hostnames: []string{host}}, nil
 }
 
 func toTolerations(spec *Spec) []v1.Toleration {
@@ -67,36 +291,223 @@ func toVolumes(spec *Spec) []v1.Volume {
 			}
 			volumes = append(volumes, volume)
 		}
+
+		if v.Claim != nil {
+			volume := v1.Volume{
+				Name: v.Claim.ID,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: v.Claim.ClaimName,
+						ReadOnly:  v.Claim.ReadOnly,
+					},
+				},
+			}
+			volumes = append(volumes, volume)
+		}
 	}
 
 	return volumes
 }
 
-func toContainers(spec *Spec) []v1.Container {
+func toContainers(spec *Spec) ([]v1.Container, error) {
 	var containers []v1.Container
 
 	for _, s := range spec.Steps {
-		container := v1.Container{
-			Name:            s.ID,
-			Image:           placeHolderImage,
-			Command:         s.Entrypoint,
-			Args:            s.Command,
-			ImagePullPolicy: toPullPolicy(s.Pull),
-			WorkingDir:      s.WorkingDir,
-			SecurityContext: &v1.SecurityContext{
-				Privileged: boolptr(s.Privileged),
-			},
-			VolumeMounts: toVolumeMounts(spec, s),
-			Env:          toEnv(s),
-			// TODO(bradrydzewski) revisit how we want to pass sensitive data
-			// to the pipeline contianers.
-			// EnvFrom:      toEnvFrom(s),
+		container, err := toContainer(spec, s)
+		if err != nil {
+			return nil, err
 		}
+		containers = append(containers, *container)
+	}
+
+	return containers, nil
+}
 
-		containers = append(containers, container)
+// toServiceContainers converts the pipeline's detached service
+// steps into restartable sidecar containers that share the
+// pipeline pod. Kubernetes forbids RestartPolicy on entries in
+// PodSpec.Containers, so these are returned for PodSpec.
+// InitContainers instead; a RestartPolicy of Always is what
+// marks an init container as a native sidecar, starting before
+// and outliving the regular step containers.
+func toServiceContainers(spec *Spec) ([]v1.Container, error) {
+	var containers []v1.Container
+
+	restartAlways := v1.ContainerRestartPolicyAlways
+	for _, s := range spec.Services {
+		container, err := toContainer(spec, s)
+		if err != nil {
+			return nil, err
+		}
+		container.RestartPolicy = &restartAlways
+		container.ReadinessProbe = toProbe(s)
+		containers = append(containers, *container)
 	}
 
-	return containers
+	return containers, nil
+}
+
+func toContainer(spec *Spec, s *Step) (*v1.Container, error) {
+	resources, err := toResources(spec, s)
+	if err != nil {
+		return nil, fmt.Errorf("step %s: %w", s.ID, err)
+	}
+
+	container := &v1.Container{
+		Name:            s.ID,
+		Image:           placeHolderImage,
+		Command:         s.Entrypoint,
+		Args:            s.Command,
+		ImagePullPolicy: toPullPolicy(s.Pull),
+		WorkingDir:      s.WorkingDir,
+		SecurityContext: toSecurityContext(s),
+		VolumeMounts:    append(toVolumeMounts(spec, s), toSecretVolumeMounts(s)...),
+		Env:             toEnv(s),
+		Resources:       resources,
+	}
+
+	return container, nil
+}
+
+// toProbe converts a step's readiness probe to the equivalent
+// Kubernetes probe. It returns nil if the step declares none.
+func toProbe(step *Step) *v1.Probe {
+	p := step.ReadinessProbe
+	if p == nil {
+		return nil
+	}
+
+	probe := &v1.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+	}
+	if p.HTTPPath != "" {
+		probe.HTTPGet = &v1.HTTPGetAction{
+			Path: p.HTTPPath,
+			Port: intstr.FromInt(int(p.HTTPPort)),
+		}
+	} else {
+		probe.TCPSocket = &v1.TCPSocketAction{
+			Port: intstr.FromInt(int(p.TCPPort)),
+		}
+	}
+	return probe
+}
+
+// toResources converts the step resource requests and limits
+// to the equivalent Kubernetes resource requirements, falling
+// back to the pipeline-wide defaults declared on the pod spec
+// for each quantity the step does not declare. It returns an error
+// if any quantity fails to parse, or if a limit is lower than
+// its corresponding request.
+func toResources(spec *Spec, step *Step) (v1.ResourceRequirements, error) {
+	requests, err := toResourceList(mergeResourceObject(step.Resources.Requests, spec.PodSpec.Resources.Requests))
+	if err != nil {
+		return v1.ResourceRequirements{}, fmt.Errorf("invalid resource requests: %w", err)
+	}
+	limits, err := toResourceList(mergeResourceObject(step.Resources.Limits, spec.PodSpec.Resources.Limits))
+	if err != nil {
+		return v1.ResourceRequirements{}, fmt.Errorf("invalid resource limits: %w", err)
+	}
+	if err := validateResourceLimits(requests, limits); err != nil {
+		return v1.ResourceRequirements{}, err
+	}
+
+	return v1.ResourceRequirements{
+		Requests: requests,
+		Limits:   limits,
+	}, nil
+}
+
+// mergeResourceObject fills in each empty field of step from the
+// pipeline-wide default, so that a step declaring only one
+// resource quantity still inherits the operator-enforced
+// defaults for the rest instead of losing them outright.
+func mergeResourceObject(step, def ResourceObject) ResourceObject {
+	if step.CPU == "" {
+		step.CPU = def.CPU
+	}
+	if step.Memory == "" {
+		step.Memory = def.Memory
+	}
+	if step.EphemeralStorage == "" {
+		step.EphemeralStorage = def.EphemeralStorage
+	}
+	return step
+}
+
+// toResourceList converts a ResourceObject to a v1.ResourceList,
+// parsing each non-empty quantity and skipping the rest.
+func toResourceList(obj ResourceObject) (v1.ResourceList, error) {
+	list := v1.ResourceList{}
+	for name, value := range map[v1.ResourceName]string{
+		v1.ResourceCPU:              obj.CPU,
+		v1.ResourceMemory:           obj.Memory,
+		v1.ResourceEphemeralStorage: obj.EphemeralStorage,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		list[name] = resource.MustParse(value)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list, nil
+}
+
+// validateResourceLimits returns an error if any limit is set
+// lower than its corresponding request.
+func validateResourceLimits(requests, limits v1.ResourceList) error {
+	for name, limit := range limits {
+		request, ok := requests[name]
+		if !ok {
+			continue
+		}
+		if limit.Cmp(request) < 0 {
+			return fmt.Errorf("resource limit for %s (%s) is lower than request (%s)", name, limit.String(), request.String())
+		}
+	}
+	return nil
+}
+
+func toSecurityContext(step *Step) *v1.SecurityContext {
+	sc := step.SecurityContext
+	context := &v1.SecurityContext{
+		Privileged:               boolptr(step.Privileged),
+		RunAsUser:                sc.RunAsUser,
+		RunAsGroup:               sc.RunAsGroup,
+		RunAsNonRoot:             sc.RunAsNonRoot,
+		AllowPrivilegeEscalation: sc.AllowPrivilegeEscalation,
+	}
+	if sc.ReadOnlyRootFilesystem {
+		context.ReadOnlyRootFilesystem = boolptr(true)
+	}
+	if sc.SeccompProfile != "" {
+		context.SeccompProfile = &v1.SeccompProfile{
+			Type:             v1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: stringptr(sc.SeccompProfile),
+		}
+	}
+	if len(sc.Capabilities.Add) > 0 || len(sc.Capabilities.Drop) > 0 {
+		context.Capabilities = &v1.Capabilities{
+			Add:  toCapabilities(sc.Capabilities.Add),
+			Drop: toCapabilities(sc.Capabilities.Drop),
+		}
+	}
+	return context
+}
+
+func toCapabilities(names []string) []v1.Capability {
+	var caps []v1.Capability
+	for _, name := range names {
+		caps = append(caps, v1.Capability(name))
+	}
+	return caps
 }
 
 func toEnv(step *Step) []v1.EnvVar {
@@ -109,12 +520,20 @@ func toEnv(step *Step) []v1.EnvVar {
 		})
 	}
 
-	// TODO(bradrydzewski) revisit how we want to pass sensitive data
-	// to the pipeline contianers.
 	for _, secret := range step.Secrets {
+		if secret.File != "" {
+			continue
+		}
 		envVars = append(envVars, v1.EnvVar{
-			Name:  secret.Env,
-			Value: string(secret.Data),
+			Name: secret.Env,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: step.ID,
+					},
+					Key: secret.Env,
+				},
+			},
 		})
 	}
 
@@ -130,18 +549,23 @@ func toEnv(step *Step) []v1.EnvVar {
 	return envVars
 }
 
-func toEnvFrom(step *Step) []v1.EnvFromSource {
-	return []v1.EnvFromSource{
-		{
-			SecretRef: &v1.SecretEnvSource{
-				LocalObjectReference: v1.LocalObjectReference{
-					Name: step.ID,
-				},
-			},
-		},
+// hasFileSecrets reports whether the step has at least one
+// secret that should be mounted as a file.
+func hasFileSecrets(step *Step) bool {
+	for _, secret := range step.Secrets {
+		if secret.File != "" {
+			return true
+		}
 	}
+	return false
 }
 
+// toSecret builds the Kubernetes Secret that backs a step's
+// secrets, regardless of whether they are exposed as environment
+// variables or mounted as files. The caller is responsible for
+// creating this Secret alongside the step's Pod, in the same
+// namespace, with an ownerReference to the Pod so it is garbage
+// collected when the Pod is removed.
 func toSecret(step *Step) *v1.Secret {
 	stringData := make(map[string]string)
 	for _, secret := range step.Secrets {
@@ -157,35 +581,119 @@ func toSecret(step *Step) *v1.Secret {
 	}
 }
 
+// secretVolumeName returns the name of the volume that mounts
+// the step's file-target secrets.
+func secretVolumeName(step *Step) string {
+	return step.ID + "-secrets"
+}
+
+// toSecretVolume returns the volume that mounts the step's
+// file-target secrets, or nil if the step has none.
+func toSecretVolume(step *Step) *v1.Volume {
+	if !hasFileSecrets(step) {
+		return nil
+	}
+
+	defaultMode := int32(0400)
+	var items []v1.KeyToPath
+	for _, secret := range step.Secrets {
+		if secret.File == "" {
+			continue
+		}
+		items = append(items, v1.KeyToPath{
+			Key:  secret.Env,
+			Path: secret.Env,
+		})
+	}
+
+	return &v1.Volume{
+		Name: secretVolumeName(step),
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName:  step.ID,
+				Items:       items,
+				DefaultMode: &defaultMode,
+			},
+		},
+	}
+}
+
+// toSecretVolumeMounts mounts each of the step's file-target
+// secrets at its configured path, projecting the single secret
+// volume multiple times via SubPath.
+func toSecretVolumeMounts(step *Step) []v1.VolumeMount {
+	var mounts []v1.VolumeMount
+	name := secretVolumeName(step)
+	for _, secret := range step.Secrets {
+		if secret.File == "" {
+			continue
+		}
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: secret.File,
+			SubPath:   secret.Env,
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+// toSecretVolumes returns the secret volumes for every step in
+// the pipeline that mounts at least one file-target secret.
+func toSecretVolumes(spec *Spec) []v1.Volume {
+	var volumes []v1.Volume
+	for _, s := range allSteps(spec) {
+		if v := toSecretVolume(s); v != nil {
+			volumes = append(volumes, *v)
+		}
+	}
+	return volumes
+}
+
+// allSteps returns every step and service in the pipeline, since
+// both are converted to containers sharing the same pod and so
+// need the same step-scoped resources (volumes, secrets, etc).
+func allSteps(spec *Spec) []*Step {
+	steps := make([]*Step, 0, len(spec.Steps)+len(spec.Services))
+	steps = append(steps, spec.Steps...)
+	steps = append(steps, spec.Services...)
+	return steps
+}
+
 func toVolumeMounts(spec *Spec, step *Step) []v1.VolumeMount {
 	var volumeMounts []v1.VolumeMount
 	for _, v := range step.Volumes {
-		id, ok := lookupVolumeID(spec, v.Name)
+		id, readOnly, ok := lookupVolumeID(spec, v.Name)
 		if !ok {
 			continue
 		}
 		volumeMounts = append(volumeMounts, v1.VolumeMount{
 			Name:      id,
 			MountPath: v.Path,
+			ReadOnly:  readOnly,
 		})
 	}
 	return volumeMounts
 }
 
 // LookupVolume is a helper function that will lookup
-// the id for a volume.
-func lookupVolumeID(spec *Spec, name string) (string, bool) {
+// the id, and whether the volume is read-only, for a volume.
+func lookupVolumeID(spec *Spec, name string) (string, bool, bool) {
 	for _, v := range spec.Volumes {
 		if v.EmptyDir != nil && v.EmptyDir.Name == name {
-			return v.EmptyDir.ID, true
+			return v.EmptyDir.ID, false, true
 		}
 
 		if v.HostPath != nil && v.HostPath.Name == name {
-			return v.HostPath.ID, true
+			return v.HostPath.ID, false, true
+		}
+
+		if v.Claim != nil && v.Claim.Name == name {
+			return v.Claim.ID, v.Claim.ReadOnly, true
 		}
 	}
 
-	return "", false
+	return "", false, false
 }
 
 func toPullPolicy(policy PullPolicy) v1.PullPolicy {
@@ -211,4 +719,4 @@ func boolptr(v bool) *bool {
 
 func stringptr(v string) *string {
 	return &v
-}
\ No newline at end of file
+}
@@ -0,0 +1,314 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Polyform License
+// that can be found in the LICENSE file.
+
+package engine
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestToResourceList(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     ResourceObject
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			obj:     ResourceObject{},
+			wantLen: 0,
+		},
+		{
+			name:    "cpu and memory",
+			obj:     ResourceObject{CPU: "100m", Memory: "128Mi"},
+			wantLen: 2,
+		},
+		{
+			name:    "invalid quantity",
+			obj:     ResourceObject{CPU: "not-a-quantity"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := toResourceList(test.obj)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %+v", test.obj)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(list) != test.wantLen {
+				t.Errorf("got %d resources, want %d", len(list), test.wantLen)
+			}
+		})
+	}
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	requests, err := toResourceList(ResourceObject{CPU: "500m", Memory: "256Mi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("limit above request", func(t *testing.T) {
+		limits, err := toResourceList(ResourceObject{CPU: "1", Memory: "512Mi"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := validateResourceLimits(requests, limits); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("limit below request", func(t *testing.T) {
+		limits, err := toResourceList(ResourceObject{CPU: "100m"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := validateResourceLimits(requests, limits); err == nil {
+			t.Error("expected error when cpu limit is lower than cpu request")
+		}
+	})
+}
+
+func TestToResourcesMergesPodDefaultsPerField(t *testing.T) {
+	spec := &Spec{
+		PodSpec: PodSpec{
+			Resources: Resources{
+				Limits: ResourceObject{CPU: "1", Memory: "512Mi"},
+			},
+		},
+	}
+	step := &Step{
+		Resources: Resources{
+			Requests: ResourceObject{CPU: "100m"},
+		},
+	}
+
+	requirements, err := toResources(spec, step)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := requirements.Limits[v1.ResourceMemory]; !ok {
+		t.Error("expected pod-wide memory limit to survive a step that only declares a cpu request")
+	}
+	if _, ok := requirements.Limits[v1.ResourceCPU]; !ok {
+		t.Error("expected pod-wide cpu limit to survive a step that only declares a cpu request")
+	}
+	if got := requirements.Requests[v1.ResourceCPU]; got.String() != "100m" {
+		t.Errorf("got cpu request %s, want 100m", got.String())
+	}
+}
+
+func TestToRegistrySecretNameMatchesPullSecrets(t *testing.T) {
+	spec := &Spec{
+		PodSpec: PodSpec{
+			Name:          "build-1",
+			Namespace:     "default",
+			RegistryAuths: []byte(`{"auths":{}}`),
+		},
+	}
+
+	secret := toRegistrySecret(spec)
+	if secret == nil {
+		t.Fatal("expected a registry secret when RegistryAuths is set")
+	}
+
+	refs := toPullSecrets(spec)
+	var found bool
+	for _, ref := range refs {
+		if ref.Name == secret.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("auto-created registry secret %q is not referenced by toPullSecrets %v", secret.Name, refs)
+	}
+}
+
+func TestToRegistrySecretNilWithoutAuths(t *testing.T) {
+	spec := &Spec{PodSpec: PodSpec{Name: "build-1"}}
+	if secret := toRegistrySecret(spec); secret != nil {
+		t.Fatalf("expected no registry secret, got %v", secret)
+	}
+}
+
+func TestToSecurityContext(t *testing.T) {
+	step := &Step{
+		Privileged: true,
+		SecurityContext: SecurityContext{
+			ReadOnlyRootFilesystem: true,
+			Capabilities: Capabilities{
+				Add:  []string{"NET_BIND_SERVICE"},
+				Drop: []string{"ALL"},
+			},
+		},
+	}
+
+	sc := toSecurityContext(step)
+
+	if sc.Privileged == nil || !*sc.Privileged {
+		t.Error("expected Privileged to be true")
+	}
+	if sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem {
+		t.Error("expected ReadOnlyRootFilesystem to be true")
+	}
+	if sc.Capabilities == nil || len(sc.Capabilities.Add) != 1 || sc.Capabilities.Add[0] != "NET_BIND_SERVICE" {
+		t.Errorf("got capabilities.add %v, want [NET_BIND_SERVICE]", sc.Capabilities)
+	}
+	if sc.Capabilities == nil || len(sc.Capabilities.Drop) != 1 || sc.Capabilities.Drop[0] != "ALL" {
+		t.Errorf("got capabilities.drop %v, want [ALL]", sc.Capabilities)
+	}
+}
+
+func TestToPodPutsServicesInInitContainers(t *testing.T) {
+	spec := &Spec{
+		PodSpec:  PodSpec{Name: "build-1"},
+		Steps:    []*Step{{ID: "step"}},
+		Services: []*Step{{ID: "service"}},
+	}
+
+	pod, err := toPod(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.RestartPolicy != nil {
+			t.Errorf("container %q in PodSpec.Containers must not set RestartPolicy, the API server rejects it", c.Name)
+		}
+		if c.Name == "service" {
+			t.Errorf("service container %q must not be in PodSpec.Containers", c.Name)
+		}
+	}
+
+	var foundService bool
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == "service" {
+			foundService = true
+			if c.RestartPolicy == nil || *c.RestartPolicy != v1.ContainerRestartPolicyAlways {
+				t.Errorf("service init container %q must set RestartPolicy: Always to behave as a sidecar", c.Name)
+			}
+		}
+	}
+	if !foundService {
+		t.Fatal("expected service container in PodSpec.InitContainers")
+	}
+}
+
+func TestToPodIncludesServiceSecretVolumes(t *testing.T) {
+	spec := &Spec{
+		PodSpec: PodSpec{Name: "build-1"},
+		Steps:   []*Step{{ID: "step"}},
+		Services: []*Step{
+			{
+				ID: "service",
+				Secrets: []*Secret{
+					{Env: "SSH_KEY", Data: []byte("file-secret"), File: "/run/secrets/ssh_key"},
+				},
+			},
+		},
+	}
+
+	pod, err := toPod(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	volumeNames := map[string]bool{}
+	for _, v := range pod.Spec.Volumes {
+		volumeNames[v.Name] = true
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		for _, m := range c.VolumeMounts {
+			if !volumeNames[m.Name] {
+				t.Errorf("container %q mounts volume %q, which has no matching entry in pod.Spec.Volumes", c.Name, m.Name)
+			}
+		}
+	}
+}
+
+func TestToEnvDoesNotLeakFileSecrets(t *testing.T) {
+	step := &Step{
+		ID: "step",
+		Secrets: []*Secret{
+			{Env: "API_TOKEN", Data: []byte("env-secret")},
+			{Env: "SSH_KEY", Data: []byte("file-secret"), File: "/run/secrets/ssh_key"},
+		},
+	}
+
+	envVars := toEnv(step)
+
+	for _, envVar := range envVars {
+		if envVar.Name == "SSH_KEY" {
+			t.Fatalf("file-target secret %q must not be exposed as an env var", envVar.Name)
+		}
+		if envVar.Name == "API_TOKEN" && (envVar.ValueFrom == nil || envVar.ValueFrom.SecretKeyRef == nil) {
+			t.Fatalf("env-target secret %q must be sourced from SecretKeyRef, not a plain value", envVar.Name)
+		}
+	}
+}
+
+func TestParseExtraHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantIP  string
+		wantErr bool
+	}{
+		{
+			name:   "ipv4",
+			input:  "foo.local:10.0.0.5",
+			wantIP: "10.0.0.5",
+		},
+		{
+			name:   "ipv6",
+			input:  "foo.local:2001:db8::1",
+			wantIP: "2001:db8::1",
+		},
+		{
+			name:    "missing colon",
+			input:   "foo.local",
+			wantErr: true,
+		},
+		{
+			name:    "missing hostname",
+			input:   ":10.0.0.5",
+			wantErr: true,
+		},
+		{
+			name:    "invalid ip",
+			input:   "foo.local:not-an-ip",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseExtraHost(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for input %q", test.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.IP != test.wantIP {
+				t.Errorf("got ip %q, want %q", got.IP, test.wantIP)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Polyform License
+// that can be found in the LICENSE file.
+
+package engine
+
+// SecurityPolicy clamps pipeline-declared security settings
+// before a pod is submitted to the cluster, so a cluster admin
+// can safely expose this engine to untrusted pipeline yaml.
+type SecurityPolicy struct {
+	// ForbidPrivileged, when true, forces every step to run
+	// unprivileged regardless of what the pipeline requests.
+	ForbidPrivileged bool
+	// ForceRunAsNonRoot, when true, forces every step's
+	// RunAsNonRoot to true.
+	ForceRunAsNonRoot bool
+	// ForceReadOnlyRootFilesystem, when true, forces every
+	// step's root filesystem to be read-only.
+	ForceReadOnlyRootFilesystem bool
+	// DropCapabilities lists capabilities that are dropped
+	// from every step, in addition to any the pipeline drops.
+	DropCapabilities []string
+}
+
+// Apply clamps the spec's steps and services in place to
+// conform to the policy. It is a no-op on a nil policy.
+func (p *SecurityPolicy) Apply(spec *Spec) {
+	if p == nil {
+		return
+	}
+	for _, step := range spec.Steps {
+		p.clamp(step)
+	}
+	for _, service := range spec.Services {
+		p.clamp(service)
+	}
+}
+
+func (p *SecurityPolicy) clamp(step *Step) {
+	if p.ForbidPrivileged {
+		step.Privileged = false
+	}
+	if p.ForceRunAsNonRoot {
+		step.SecurityContext.RunAsNonRoot = boolptr(true)
+	}
+	if p.ForceReadOnlyRootFilesystem {
+		step.SecurityContext.ReadOnlyRootFilesystem = true
+	}
+	if len(p.DropCapabilities) > 0 {
+		step.SecurityContext.Capabilities.Add = removeCapabilities(
+			step.SecurityContext.Capabilities.Add,
+			p.DropCapabilities,
+		)
+		step.SecurityContext.Capabilities.Drop = append(
+			step.SecurityContext.Capabilities.Drop,
+			p.DropCapabilities...,
+		)
+	}
+}
+
+// removeCapabilities returns add with every capability in drop
+// removed, so a policy-enforced drop cannot be contradicted by
+// a pipeline-declared add of the same capability.
+func removeCapabilities(add, drop []string) []string {
+	dropped := make(map[string]struct{}, len(drop))
+	for _, name := range drop {
+		dropped[name] = struct{}{}
+	}
+
+	var kept []string
+	for _, name := range add {
+		if _, ok := dropped[name]; ok {
+			continue
+		}
+		kept = append(kept, name)
+	}
+	return kept
+}
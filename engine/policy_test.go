@@ -0,0 +1,51 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Polyform License
+// that can be found in the LICENSE file.
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecurityPolicyApplyCoversServices(t *testing.T) {
+	policy := &SecurityPolicy{ForbidPrivileged: true}
+
+	spec := &Spec{
+		Steps:    []*Step{{ID: "step", Privileged: true}},
+		Services: []*Step{{ID: "service", Privileged: true}},
+	}
+
+	policy.Apply(spec)
+
+	if spec.Steps[0].Privileged {
+		t.Errorf("expected step privileged to be clamped to false")
+	}
+	if spec.Services[0].Privileged {
+		t.Errorf("expected service privileged to be clamped to false, policy must not be bypassable via a service step")
+	}
+}
+
+func TestSecurityPolicyApplyDropWinsOverAdd(t *testing.T) {
+	policy := &SecurityPolicy{DropCapabilities: []string{"SYS_ADMIN"}}
+
+	step := &Step{
+		SecurityContext: SecurityContext{
+			Capabilities: Capabilities{
+				Add: []string{"SYS_ADMIN", "NET_BIND_SERVICE"},
+			},
+		},
+	}
+	spec := &Spec{Steps: []*Step{step}}
+
+	policy.Apply(spec)
+
+	want := []string{"NET_BIND_SERVICE"}
+	if !reflect.DeepEqual(step.SecurityContext.Capabilities.Add, want) {
+		t.Errorf("got Add %v, want %v: a policy-dropped capability must not survive in Add", step.SecurityContext.Capabilities.Add, want)
+	}
+	if len(step.SecurityContext.Capabilities.Drop) != 1 || step.SecurityContext.Capabilities.Drop[0] != "SYS_ADMIN" {
+		t.Errorf("got Drop %v, want [SYS_ADMIN]", step.SecurityContext.Capabilities.Drop)
+	}
+}
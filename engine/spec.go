@@ -0,0 +1,258 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Polyform License
+// that can be found in the LICENSE file.
+
+package engine
+
+// placeHolderImage is the placeholder image used to create the
+// pod, before the real pipeline image is injected by the scheduler.
+const placeHolderImage = "drone/placeholder:1"
+
+// PullPolicy defines the pull policy for a container image.
+type PullPolicy int
+
+// pull policy enumeration.
+const (
+	PullDefault PullPolicy = iota
+	PullAlways
+	PullNever
+	PullIfNotExists
+)
+
+// Spec provides the pipeline spec. This provides the
+// required instructions for converting a pipeline into
+// a kubernetes pod.
+type Spec struct {
+	PodSpec  PodSpec
+	Steps    []*Step
+	Services []*Step
+	Volumes  []*Volume
+}
+
+// PodSpec provides the pod spec.
+type PodSpec struct {
+	Name               string
+	Namespace          string
+	Annotations        map[string]string
+	Labels             map[string]string
+	NodeSelector       map[string]string
+	ServiceAccountName string
+	Tolerations        []Toleration
+	// Resources, when set, provide pipeline-wide default
+	// requests and limits. Each quantity is applied to every
+	// step that does not declare that quantity itself.
+	Resources   Resources
+	HostAliases []HostAlias
+	// PullSecrets names existing image pull secrets, in the
+	// pod's namespace, to attach to the pod.
+	PullSecrets []string
+	// RegistryAuths, when set, holds a resolved docker config
+	// json (as produced by the runner's registry plugin) that
+	// is auto-created as a docker-config Secret and attached
+	// to the pod as an additional ImagePullSecret, the same way
+	// the docker runner threads `auths` into the daemon.
+	RegistryAuths             []byte
+	SecurityContext           PodSecurityContext
+	Affinity                  Affinity
+	TopologySpreadConstraints []TopologySpreadConstraint
+}
+
+// Affinity configures the node and pod (anti-)affinity rules
+// used to schedule the pipeline pod.
+type Affinity struct {
+	NodeAffinity    *NodeAffinity
+	PodAffinity     *PodAffinity
+	PodAntiAffinity *PodAffinity
+}
+
+// NodeAffinity constrains which nodes the pod can be
+// scheduled onto, based on node labels.
+type NodeAffinity struct {
+	Required  []MatchExpression
+	Preferred []WeightedMatchExpression
+}
+
+// MatchExpression is a label selector requirement.
+type MatchExpression struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// WeightedMatchExpression is a MatchExpression with a
+// scheduling preference weight, from 1 to 100.
+type WeightedMatchExpression struct {
+	Weight int32
+	MatchExpression
+}
+
+// PodAffinity constrains which nodes the pod can be scheduled
+// onto, based on the labels of pods already running there.
+type PodAffinity struct {
+	Required  []PodAffinityTerm
+	Preferred []WeightedPodAffinityTerm
+}
+
+// PodAffinityTerm selects the pods, and the topology domain
+// they must share or avoid, for a PodAffinity rule.
+type PodAffinityTerm struct {
+	LabelSelector []MatchExpression
+	TopologyKey   string
+}
+
+// WeightedPodAffinityTerm is a PodAffinityTerm with a
+// scheduling preference weight, from 1 to 100.
+type WeightedPodAffinityTerm struct {
+	Weight int32
+	PodAffinityTerm
+}
+
+// TopologySpreadConstraint spreads pipeline pods evenly across
+// a topology domain, such as zone or hostname.
+type TopologySpreadConstraint struct {
+	MaxSkew           int32
+	TopologyKey       string
+	WhenUnsatisfiable string
+	LabelSelector     []MatchExpression
+}
+
+// PodSecurityContext configures the security attributes
+// applied to every container in the pod.
+type PodSecurityContext struct {
+	RunAsUser          *int64
+	FSGroup            *int64
+	SupplementalGroups []int64
+	SeccompProfile     string
+}
+
+// HostAlias maps a single IP address to one or more hostnames,
+// and is injected into the pod's /etc/hosts.
+type HostAlias struct {
+	IP        string
+	Hostnames []string
+}
+
+// Toleration provides a pod toleration.
+type Toleration struct {
+	Operator          string
+	Effect            string
+	Value             string
+	TolerationSeconds int
+}
+
+// Step configures a pipeline step within a kubernetes pod.
+type Step struct {
+	ID              string
+	Name            string
+	Entrypoint      []string
+	Command         []string
+	Envs            map[string]string
+	Secrets         []*Secret
+	Volumes         []*VolumeMount
+	Pull            PullPolicy
+	Privileged      bool
+	WorkingDir      string
+	Resources       Resources
+	SecurityContext SecurityContext
+	// ReadinessProbe, set on a Service step, determines when
+	// the service is considered available to dependent steps.
+	ReadinessProbe *Probe
+}
+
+// Probe declares a TCP or HTTP readiness check for a service
+// container sharing the pipeline pod.
+type Probe struct {
+	TCPPort             int32
+	HTTPPath            string
+	HTTPPort            int32
+	InitialDelaySeconds int32
+	PeriodSeconds       int32
+	TimeoutSeconds      int32
+}
+
+// SecurityContext configures the security attributes applied
+// to a single step's container.
+type SecurityContext struct {
+	RunAsUser                *int64
+	RunAsGroup               *int64
+	RunAsNonRoot             *bool
+	ReadOnlyRootFilesystem   bool
+	AllowPrivilegeEscalation *bool
+	SeccompProfile           string
+	Capabilities             Capabilities
+}
+
+// Capabilities adds and drops linux capabilities on a
+// step's container.
+type Capabilities struct {
+	Add  []string
+	Drop []string
+}
+
+// Resources describes the compute resource requests and
+// limits applied to a step's container.
+type Resources struct {
+	Limits   ResourceObject
+	Requests ResourceObject
+}
+
+// ResourceObject describes a set of resource quantities.
+// Each field is a resource.Quantity string, e.g. "100m" or
+// "128Mi", and is left empty when not declared.
+type ResourceObject struct {
+	CPU              string
+	Memory           string
+	EphemeralStorage string
+}
+
+// Secret represents a container secret.
+type Secret struct {
+	ID   string
+	Name string
+	Env  string
+	Data []byte
+	Mask bool
+	// File, when set, is the absolute path at which the
+	// secret is mounted as a file rather than injected as
+	// an environment variable.
+	File string
+}
+
+// Volume that can be mounted by containers.
+type Volume struct {
+	EmptyDir *VolumeEmptyDir
+	HostPath *VolumeHostPath
+	Claim    *VolumeClaim
+}
+
+// VolumeMount describes a mounting of a Volume within a container.
+type VolumeMount struct {
+	Name string
+	Path string
+}
+
+// VolumeClaim mounts an existing PersistentVolumeClaim into the
+// container, allowing a cache or workspace to persist across
+// pipeline runs on clusters with a dynamic provisioner.
+type VolumeClaim struct {
+	ID        string
+	Name      string
+	ClaimName string
+	ReadOnly  bool
+}
+
+// VolumeEmptyDir mounts a temporary directory from the
+// host node's filesystem into the container.
+type VolumeEmptyDir struct {
+	ID     string
+	Name   string
+	Medium string
+}
+
+// VolumeHostPath mounts a file or directory from the
+// host node's filesystem into the container.
+type VolumeHostPath struct {
+	ID   string
+	Name string
+	Path string
+}